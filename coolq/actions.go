@@ -0,0 +1,203 @@
+package coolq
+
+// 酷q/go-cqhttp 支持的更多 api 动作名称
+// 参考 OneBot/go-cqhttp 的动作列表补全，与 cqclient.go 中用到的
+// ActionSendGroupMsg 等已有常量风格保持一致
+const (
+	ActionGetLoginInfo         = "get_login_info"
+	ActionGetFriendList        = "get_friend_list"
+	ActionGetGroupList         = "get_group_list"
+	ActionGetGroupMemberInfo   = "get_group_member_info"
+	ActionGetGroupMemberList   = "get_group_member_list"
+	ActionSendMsg              = "send_msg"
+	ActionDeleteMsg            = "delete_msg"
+	ActionGetMsg               = "get_msg"
+	ActionGetForwardMsg        = "get_forward_msg"
+	ActionSendGroupForwardMsg  = "send_group_forward_msg"
+	ActionSetGroupCard         = "set_group_card"
+	ActionSetGroupName         = "set_group_name"
+	ActionSetGroupLeave        = "set_group_leave"
+	ActionSetGroupAdmin        = "set_group_admin"
+	ActionSetGroupAnonymousBan = "set_group_anonymous_ban"
+	ActionSetFriendAddRequest  = "set_friend_add_request"
+	ActionSetGroupAddRequest   = "set_group_add_request"
+	ActionGetImage             = "get_image"
+	ActionCanSendImage         = "can_send_image"
+	ActionCanSendRecord        = "can_send_record"
+	ActionGetCookies           = "get_cookies"
+	ActionGetCsrfToken         = "get_csrf_token"
+)
+
+// CQTypeGetLoginInfo get_login_info 的返回数据
+type CQTypeGetLoginInfo struct {
+	UserID   int64  `json:"user_id"`
+	Nickname string `json:"nickname"`
+}
+
+// CQTypeFriend 好友信息
+type CQTypeFriend struct {
+	UserID   int64  `json:"user_id"`
+	Nickname string `json:"nickname"`
+	Remark   string `json:"remark"`
+}
+
+// CQTypeGroup 群信息
+type CQTypeGroup struct {
+	GroupID        int64  `json:"group_id"`
+	GroupName      string `json:"group_name"`
+	MemberCount    int64  `json:"member_count"`
+	MaxMemberCount int64  `json:"max_member_count"`
+}
+
+// CQTypeGetGroupMemberInfo get_group_member_info 的参数
+type CQTypeGetGroupMemberInfo struct {
+	GroupID int64 `json:"group_id"`
+	UserID  int64 `json:"user_id"`
+	NoCache bool  `json:"no_cache"`
+}
+
+// CQTypeGroupMember 群成员信息
+type CQTypeGroupMember struct {
+	GroupID      int64  `json:"group_id"`
+	UserID       int64  `json:"user_id"`
+	Nickname     string `json:"nickname"`
+	Card         string `json:"card"`
+	Role         string `json:"role"`
+	Title        string `json:"title"`
+	Unfriendly   bool   `json:"unfriendly"`
+	JoinTime     int64  `json:"join_time"`
+	LastSentTime int64  `json:"last_sent_time"`
+}
+
+// CQTypeGetGroupMemberList get_group_member_list 的参数
+type CQTypeGetGroupMemberList struct {
+	GroupID int64 `json:"group_id"`
+}
+
+// CQTypeSendMsg send_msg 的参数，可同时用于群聊和私聊
+type CQTypeSendMsg struct {
+	MessageType string `json:"message_type,omitempty"`
+	GroupID     int64  `json:"group_id,omitempty"`
+	UserID      int64  `json:"user_id,omitempty"`
+	Message     string `json:"message"`
+}
+
+// CQTypeSendMsgResult send_msg/send_group_msg/send_private_msg 的返回数据
+type CQTypeSendMsgResult struct {
+	MessageID int32 `json:"message_id"`
+}
+
+// CQTypeDeleteMsg delete_msg 的参数
+type CQTypeDeleteMsg struct {
+	MessageID int32 `json:"message_id"`
+}
+
+// CQTypeGetMsg get_msg 的参数
+type CQTypeGetMsg struct {
+	MessageID int32 `json:"message_id"`
+}
+
+// CQTypeMessage get_msg 的返回数据
+type CQTypeMessage struct {
+	MessageID int32       `json:"message_id"`
+	RealID    int32       `json:"real_id"`
+	Sender    interface{} `json:"sender"`
+	Time      int64       `json:"time"`
+	Message   interface{} `json:"message"`
+}
+
+// CQTypeGetForwardMsg get_forward_msg 的参数
+type CQTypeGetForwardMsg struct {
+	ID string `json:"id"`
+}
+
+// CQTypeForwardNode 合并转发节点，既用作 get_forward_msg 的返回数据，也用作
+// send_group_forward_msg 的参数
+type CQTypeForwardNode struct {
+	UserID   int64       `json:"user_id"`
+	Nickname string      `json:"nickname"`
+	Content  interface{} `json:"content"`
+}
+
+// CQTypeSendGroupForwardMsg send_group_forward_msg 的参数
+type CQTypeSendGroupForwardMsg struct {
+	GroupID int64               `json:"group_id"`
+	Message []CQTypeForwardNode `json:"messages"`
+}
+
+// CQTypeSetGroupCard set_group_card 的参数
+type CQTypeSetGroupCard struct {
+	GroupID int64  `json:"group_id"`
+	UserID  int64  `json:"user_id"`
+	Card    string `json:"card"`
+}
+
+// CQTypeSetGroupName set_group_name 的参数
+type CQTypeSetGroupName struct {
+	GroupID   int64  `json:"group_id"`
+	GroupName string `json:"group_name"`
+}
+
+// CQTypeSetGroupLeave set_group_leave 的参数
+type CQTypeSetGroupLeave struct {
+	GroupID   int64 `json:"group_id"`
+	IsDismiss bool  `json:"is_dismiss"`
+}
+
+// CQTypeSetGroupAdmin set_group_admin 的参数
+type CQTypeSetGroupAdmin struct {
+	GroupID int64 `json:"group_id"`
+	UserID  int64 `json:"user_id"`
+	Enable  bool  `json:"enable"`
+}
+
+// CQTypeSetGroupAnonymousBan set_group_anonymous_ban 的参数
+type CQTypeSetGroupAnonymousBan struct {
+	GroupID   int64       `json:"group_id"`
+	Flag      string      `json:"flag,omitempty"`
+	Anonymous interface{} `json:"anonymous,omitempty"`
+	Duration  int64       `json:"duration"`
+}
+
+// CQTypeSetFriendAddRequest set_friend_add_request 的参数
+type CQTypeSetFriendAddRequest struct {
+	Flag    string `json:"flag"`
+	Approve bool   `json:"approve"`
+	Remark  string `json:"remark,omitempty"`
+}
+
+// CQTypeSetGroupAddRequest set_group_add_request 的参数
+type CQTypeSetGroupAddRequest struct {
+	Flag    string `json:"flag"`
+	SubType string `json:"sub_type"`
+	Approve bool   `json:"approve"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// CQTypeGetImage get_image 的参数
+type CQTypeGetImage struct {
+	File string `json:"file"`
+}
+
+// CQTypeImageInfo get_image 的返回数据
+type CQTypeImageInfo struct {
+	Size     int64  `json:"size"`
+	Filename string `json:"filename"`
+	URL      string `json:"url"`
+}
+
+// CQTypeCanSend can_send_image/can_send_record 的返回数据
+type CQTypeCanSend struct {
+	Yes bool `json:"yes"`
+}
+
+// CQTypeCookies get_cookies 的参数与返回数据
+type CQTypeCookies struct {
+	Domain  string `json:"domain,omitempty"`
+	Cookies string `json:"cookies"`
+}
+
+// CQTypeCsrfToken get_csrf_token 的返回数据
+type CQTypeCsrfToken struct {
+	Token int32 `json:"token"`
+}
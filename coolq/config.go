@@ -0,0 +1,171 @@
+package coolq
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/hjson/hjson-go"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/haruno-bot/haruno/logger"
+)
+
+// Config coolq 客户端的启动配置，对应配置文件里的顶层字段
+type Config struct {
+	AccessToken   string                     `json:"access_token"`
+	WSURL         string                     `json:"ws_url"`
+	HTTPURL       string                     `json:"http_url"`
+	NickNames     []string                   `json:"nick_names"`
+	SuperUsers    []int64                    `json:"super_users"`
+	CommandStarts []string                   `json:"command_starts"`
+	LogLevel      string                     `json:"log_level"`
+	Plugins       map[string]json.RawMessage `json:"plugins"`
+}
+
+// parseConfigFile 读取配置文件，按后缀名支持 yaml/json/hjson 三种格式，
+// 统一转换成 json 再解码进 Config，避免三套格式各写一遍解析逻辑
+func parseConfigFile(path string) (*Config, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(raw, &generic); err != nil {
+			return nil, err
+		}
+		generic = normalizeYAML(generic)
+	case ".json":
+		if err := json.Unmarshal(raw, &generic); err != nil {
+			return nil, err
+		}
+	case ".hjson":
+		if err := hjson.Unmarshal(raw, &generic); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("coolq: unsupported config extension %q", filepath.Ext(path))
+	}
+	jsonBytes, err := json.Marshal(generic)
+	if err != nil {
+		return nil, err
+	}
+	cfg := new(Config)
+	if err := json.Unmarshal(jsonBytes, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// normalizeYAML 把 yaml.Unmarshal 产出的 map[interface{}]interface{} 递归转换成
+// map[string]interface{}，否则后续 json.Marshal 会报错
+func normalizeYAML(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			m[fmt.Sprintf("%v", k)] = normalizeYAML(val)
+		}
+		return m
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeYAML(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// applyConfig 把解析好的配置写入 Client，并保存各插件的配置原文供 PluginConfig 使用
+func (c *cqclient) applyConfig(cfg *Config) {
+	c.mu.Lock()
+	c.nickNames = cfg.NickNames
+	c.superUsers = cfg.SuperUsers
+	c.commandStarts = cfg.CommandStarts
+	c.pluginConfig = cfg.Plugins
+	c.mu.Unlock()
+	if cfg.LogLevel != "" {
+		logger.SetLevel(cfg.LogLevel)
+	}
+}
+
+// PluginConfig 解码名为 name 的插件的配置段，供 PluginInterface.Load() 实现调用
+// 找不到对应配置段时返回 error，插件可以选择忽略（使用默认配置）
+func (c *cqclient) PluginConfig(name string, out interface{}) error {
+	c.mu.Lock()
+	raw, ok := c.pluginConfig[name]
+	c.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("coolq: no config section for plugin %s", name)
+	}
+	return json.Unmarshal(raw, out)
+}
+
+// LoadConfig 从配置文件启动整个客户端：初始化、建立连接、注册插件，
+// 并监听文件变化做热更新（重新执行各插件的 Load/Loaded，不需要重启进程）
+func LoadConfig(path string) error {
+	return Client.LoadConfig(path)
+}
+
+func (c *cqclient) LoadConfig(path string) error {
+	cfg, err := parseConfigFile(path)
+	if err != nil {
+		return err
+	}
+	c.applyConfig(cfg)
+	c.Initialize(cfg.AccessToken)
+	c.Connect(cfg.WSURL, cfg.HTTPURL)
+	c.RegisterAllPlugins()
+	return c.watchConfig(path)
+}
+
+// watchConfig 监听配置文件变化，变化时重新解析并热更新插件
+func (c *cqclient) watchConfig(path string) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return err
+	}
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != filepath.Clean(path) {
+					continue
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+				cfg, err := parseConfigFile(path)
+				if err != nil {
+					logger.Errorf("coolq: reload config %s failed: %v", path, err)
+					continue
+				}
+				c.applyConfig(cfg)
+				// 重新跑一遍 Load/Loaded，RegisterAllPlugins 本身就是在
+				// c.mu 保护下逐个插件替换 pluginEntries，天然支持热更新
+				c.RegisterAllPlugins()
+				logger.Successf("coolq: config %s reloaded", path)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logger.Errorf("coolq: config watcher error: %v", err)
+			}
+		}
+	}()
+	return nil
+}
@@ -1,15 +1,18 @@
 package coolq
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"runtime"
 	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
 
 	"github.com/haruno-bot/haruno/clients"
+	"github.com/haruno-bot/haruno/coolq/message"
 	"github.com/haruno-bot/haruno/logger"
 )
 
@@ -27,19 +30,51 @@ type pluginEntry struct {
 	keys     []string
 	fitlers  map[string]Filter
 	handlers map[string]Handler
+	clients  []string // 插件选择接收事件的客户端名单，空表示不限制
+}
+
+// clientScoped 可选接口，插件实现它来声明自己只关心哪些客户端的事件
+// 不实现时默认接收所有客户端（包括 Manager 下的多账号）的事件
+type clientScoped interface {
+	Clients() []string
+}
+
+// acceptsClient entry.clients 为空表示不限制，否则必须在名单里
+func (e pluginEntry) acceptsClient(name string) bool {
+	if len(e.clients) == 0 {
+		return true
+	}
+	for _, n := range e.clients {
+		if n == name {
+			return true
+		}
+	}
+	return false
 }
 
 // cqclient 酷q机器人连接客户端
 // 为了安全起见，暂时不允许在包外额外创建
 type cqclient struct {
-	mu            sync.Mutex
-	token         string
-	apiConn       *clients.WSClient
-	eventConn     *clients.WSClient
-	httpConn      *clients.HTTPClient
-	apiURL        string
-	pluginEntries map[string]pluginEntry
-	echoqueue     map[int64]bool
+	mu              sync.Mutex
+	token           string
+	apiConn         *clients.WSClient
+	eventConn       *clients.WSClient
+	httpConn        *clients.HTTPClient
+	apiURL          string
+	pluginEntries   map[string]pluginEntry
+	echoqueue       map[int64]chan *CQResponse
+	nextEcho        int64
+	httpEventServer *eventHTTPServer
+	messageFormat   MessageFormat
+	middlewares     []Middleware
+	pool            *workerPool
+	poolSize        int
+	poolQueueSize   int
+	nickNames       []string
+	superUsers      []int64
+	commandStarts   []string
+	pluginConfig    map[string]json.RawMessage
+	clientName      string
 }
 
 func handleConnect(conn *clients.WSClient) {
@@ -73,6 +108,9 @@ func (c *cqclient) RegisterAllPlugins() {
 			fitlers:  make(map[string]Filter),
 			handlers: make(map[string]Handler),
 		}
+		if cs, ok := plug.(clientScoped); ok {
+			entry.clients = cs.Clients()
+		}
 		noFilterHanlers := make([]Handler, 0)
 		// 对应filter的key寻找相应的handler， 没有的话则给出警告
 		for key, filter := range pluginFilters {
@@ -111,13 +149,110 @@ func (c *cqclient) deqEcho(echo int64) {
 	delete(c.echoqueue, echo)
 }
 
+// enqEcho 注册一个等待响应的 echo，返回一个用于接收 *CQResponse 的channel
+// channel 带 1 的缓冲，即使调用方已经放弃等待（超时/取消），
+// apiConn.OnMessage 往里写入也不会阻塞
+func (c *cqclient) enqEcho() (int64, chan *CQResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nextEcho++
+	echo := c.nextEcho
+	ch := make(chan *CQResponse, 1)
+	c.echoqueue[echo] = ch
+	return echo, ch
+}
+
+// dispatchEvent 将一个上报事件分发给所有插件
+// 无论事件是从 ws 上报服务读到的，还是从反向 http 上报服务收到的，
+// 最终都会走到这里，保证两种上报方式命中同一份 pluginEntries
+// handler 不再各自起一个 goroutine，而是提交给固定大小的 worker pool，
+// 避免活跃群里 goroutine 数量无限增长
+// 分发前会把事件打上 ClientName，标明它来自 Manager 管理的哪一个客户端
+// 每个 job 会拿到事件的独立副本，handler 之间（比如 CommandStart 裁剪前缀）
+// 互不影响彼此看到的 *CQEvent
+// pluginEntries 先在 c.mu 下整体拷贝一份再遍历：RegisterAllPlugins 热更新时
+// 会在持锁状态下逐个替换 c.pluginEntries[name]，如果这里不拷贝快照，
+// 遍历途中碰上替换会直接 panic（concurrent map iteration and map write）
+func (c *cqclient) dispatchEvent(event *CQEvent) {
+	event.ClientName = c.clientName
+	// 不管事件是从 ws OnMessage 读到的还是反向 http 上报收到的，都在这里统一
+	// 把原始的 Message 解析成 []Segment，而不是留给每个 filter/handler 各自解析
+	event.ParsedMessage = message.Parse(event.Message)
+	c.mu.Lock()
+	entries := make(map[string]pluginEntry, len(c.pluginEntries))
+	for name, entry := range c.pluginEntries {
+		entries[name] = entry
+	}
+	c.mu.Unlock()
+	for name, entry := range entries {
+		if !entry.acceptsClient(c.clientName) {
+			continue
+		}
+		entry := entry
+		// 先提交没有key的回调
+		eventCopy := *event
+		c.pool.submit(dispatchJob{
+			pluginName: name,
+			key:        noFilterKey,
+			handler:    c.chain(entry.handlers[noFilterKey]),
+			event:      &eventCopy,
+		})
+		// 再提交所有的 filter 和 handler 对
+		for _, key := range entry.keys {
+			key := key
+			filteredHandler := c.chain(func(event *CQEvent) {
+				if entry.fitlers[key](event) {
+					entry.handlers[key](event)
+				}
+			})
+			eventCopy := *event
+			c.pool.submit(dispatchJob{
+				pluginName: name,
+				key:        key,
+				handler:    filteredHandler,
+				event:      &eventCopy,
+			})
+		}
+	}
+}
+
+// InitOption Initialize 的可选配置项
+type InitOption func(*cqclient)
+
+// WithWorkerPoolSize 设置事件分发 worker pool 的并发 worker 数量
+// 不设置时默认为 runtime.NumCPU()*4
+func WithWorkerPoolSize(size int) InitOption {
+	return func(c *cqclient) {
+		c.poolSize = size
+	}
+}
+
+// WithWorkerQueueSize 设置 worker pool 任务队列的长度，队列满时新事件会被丢弃
+// 不设置时默认为 poolSize*64
+func WithWorkerQueueSize(size int) InitOption {
+	return func(c *cqclient) {
+		c.poolQueueSize = size
+	}
+}
+
 // Initialize 初始化客户端
 // token 酷q机器人的access token
-func (c *cqclient) Initialize(token string) {
+func (c *cqclient) Initialize(token string, opts ...InitOption) {
 	c.token = token
 	c.httpConn = clients.NewHTTPClient()
 	c.httpConn.Header.Set("Authorization", fmt.Sprintf("Token %s", c.token))
 
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.poolSize <= 0 {
+		c.poolSize = runtime.NumCPU() * 4
+	}
+	if c.poolQueueSize <= 0 {
+		c.poolQueueSize = c.poolSize * 64
+	}
+	c.pool = newWorkerPool(c.poolSize, c.poolQueueSize)
+
 	c.apiConn.Name = "coolq api conn"
 	c.eventConn.Name = "coolq event conn"
 	// 注册连接事件回调
@@ -138,10 +273,13 @@ func (c *cqclient) Initialize(token string) {
 			logger.Field(c.apiConn.Name).Errorf("on message error %v", err)
 			return
 		}
-		// echo队列 - 确定发送消息是否超时
-		echo := msg.Echo
-		if c.echoqueue[echo] {
-			c.deqEcho(echo)
+		// 按 echo 找到对应的等待者，把响应递给它
+		c.mu.Lock()
+		ch, ok := c.echoqueue[msg.Echo]
+		delete(c.echoqueue, msg.Echo)
+		c.mu.Unlock()
+		if ok {
+			ch <- msg
 		}
 	}
 	// 注册上报事件回调
@@ -152,38 +290,8 @@ func (c *cqclient) Initialize(token string) {
 			logger.Field(c.eventConn.Name).Errorf("on message error %v", err)
 			return
 		}
-		for name, entry := range c.pluginEntries {
-			// 先异步处理没有key的回调
-			go entry.handlers[noFilterKey](event)
-			// 一次异步执行所有的 filter 和 handler 对
-			for _, key := range entry.keys {
-				go func(key string, name string) {
-					if c.pluginEntries[name].fitlers[key](event) {
-						c.pluginEntries[name].handlers[key](event)
-					}
-				}(key, name)
-			}
-		}
+		c.dispatchEvent(event)
 	}
-
-	// 定时清理echo队列 (30s)
-	go func() {
-		ticker := time.NewTicker(timeForWait * time.Second)
-		defer ticker.Stop()
-		for {
-			select {
-			case <-ticker.C:
-				now := time.Now().Unix()
-				for echo, state := range c.echoqueue {
-					// 对于超过30s未响应的给出提示
-					if state && now-echo > timeForWait {
-						logger.Errorf("(echo) id = %d response time out (30s)", echo)
-						c.deqEcho(echo)
-					}
-				}
-			}
-		}
-	}()
 }
 
 // Connect 连接远程酷q api服务
@@ -217,79 +325,97 @@ func (c *cqclient) APISendJSON(data interface{}) {
 	c.apiConn.Send(websocket.TextMessage, msg)
 }
 
-// SendGroupMsg 发送群消息
-// websocket 接口
-func (c *cqclient) SendGroupMsg(groupID int64, message string) {
+// CallContext 通过 ws 同步调用 api，按 echo 关联请求与响应
+// 在 ctx 被取消或者等待超过 timeForWait(30s) 时返回 error，不会无限期阻塞
+func (c *cqclient) CallContext(ctx context.Context, action string, params interface{}) (*CQResponse, error) {
+	if !c.IsAPIOk() {
+		return nil, fmt.Errorf("coolq: ws api conn is not connected")
+	}
+	echo, ch := c.enqEcho()
 	payload := &CQWSMessage{
-		Action: ActionSendGroupMsg,
-		Params: CQTypeSendGroupMsg{
-			GroupID: groupID,
-			Message: message,
-		},
-		Echo: time.Now().Unix(),
+		Action: action,
+		Params: params,
+		Echo:   echo,
 	}
 	c.APISendJSON(payload)
+	timer := time.NewTimer(timeForWait * time.Second)
+	defer timer.Stop()
+	select {
+	case res := <-ch:
+		if res.RetCode != 0 {
+			return res, fmt.Errorf("coolq: action %s failed with ret_code %d", action, res.RetCode)
+		}
+		return res, nil
+	case <-timer.C:
+		c.deqEcho(echo)
+		return nil, fmt.Errorf("coolq: (echo) id = %d response time out (%ds)", echo, timeForWait)
+	case <-ctx.Done():
+		c.deqEcho(echo)
+		return nil, ctx.Err()
+	}
+}
+
+// SendGroupMsg 发送群消息
+// websocket 接口
+func (c *cqclient) SendGroupMsg(groupID int64, message string) (*CQTypeSendMsgResult, error) {
+	res, err := c.CallContext(context.Background(), ActionSendGroupMsg, CQTypeSendGroupMsg{
+		GroupID: groupID,
+		Message: message,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := new(CQTypeSendMsgResult)
+	return result, decodeCQData(res.Data, result)
 }
 
 // SendPrivateMsg 发送私聊消息
 // websocket 接口
-func (c *cqclient) SendPrivateMsg(userID int64, message string) {
-	payload := &CQWSMessage{
-		Action: ActionSendPrivateMsg,
-		Params: CQTypeSendPrivateMsg{
-			UserID:  userID,
-			Message: message,
-		},
-		Echo: time.Now().Unix(),
+func (c *cqclient) SendPrivateMsg(userID int64, message string) (*CQTypeSendMsgResult, error) {
+	res, err := c.CallContext(context.Background(), ActionSendPrivateMsg, CQTypeSendPrivateMsg{
+		UserID:  userID,
+		Message: message,
+	})
+	if err != nil {
+		return nil, err
 	}
-	c.APISendJSON(payload)
+	result := new(CQTypeSendMsgResult)
+	return result, decodeCQData(res.Data, result)
 }
 
 // SetGroupKick 群组踢人
 // reject 是否拒绝加群申请
 // websocket 接口
-func (c *cqclient) SetGroupKick(groupID, userID int64, reject bool) {
-	payload := &CQWSMessage{
-		Action: ActionSetGroupKick,
-		Params: CQTypeSetGroupKick{
-			GroupID:          groupID,
-			UserID:           userID,
-			RejectAddRequest: reject,
-		},
-		Echo: time.Now().Unix(),
-	}
-	c.APISendJSON(payload)
+func (c *cqclient) SetGroupKick(groupID, userID int64, reject bool) error {
+	_, err := c.CallContext(context.Background(), ActionSetGroupKick, CQTypeSetGroupKick{
+		GroupID:          groupID,
+		UserID:           userID,
+		RejectAddRequest: reject,
+	})
+	return err
 }
 
 // SetGroupBan 群组单人禁言
 // duration 禁言时长，单位秒，0 表示取消禁言
 // websocket 接口
-func (c *cqclient) SetGroupBan(groupID, userID int64, duration int64) {
-	payload := &CQWSMessage{
-		Action: ActionSetGroupBan,
-		Params: CQTypeSetGroupBan{
-			GroupID:  groupID,
-			UserID:   userID,
-			Duration: duration,
-		},
-		Echo: time.Now().Unix(),
-	}
-	c.APISendJSON(payload)
+func (c *cqclient) SetGroupBan(groupID, userID int64, duration int64) error {
+	_, err := c.CallContext(context.Background(), ActionSetGroupBan, CQTypeSetGroupBan{
+		GroupID:  groupID,
+		UserID:   userID,
+		Duration: duration,
+	})
+	return err
 }
 
 // SetGroupWholeBan 群组全员禁言
 // enable 是否禁言
 // websocket 接口
-func (c *cqclient) SetGroupWholeBan(groupID int64, enable bool) {
-	payload := &CQWSMessage{
-		Action: ActionSetGroupWholeBan,
-		Params: CQTypeSetGroupWholeBan{
-			GroupID: groupID,
-			Enable:  enable,
-		},
-		Echo: time.Now().Unix(),
-	}
-	c.APISendJSON(payload)
+func (c *cqclient) SetGroupWholeBan(groupID int64, enable bool) error {
+	_, err := c.CallContext(context.Background(), ActionSetGroupWholeBan, CQTypeSetGroupWholeBan{
+		GroupID: groupID,
+		Enable:  enable,
+	})
+	return err
 }
 
 func warnHTTPApiURLNotSet() {
@@ -333,9 +459,286 @@ func (c *cqclient) GetStatus() *CQTypeGetStatus {
 	return status
 }
 
+// Call 同步调用 api，通过 http 接口发送 action + params 并直接拿到响应
+// 适用于 GetStatus 之外那些需要真正拿到返回值（而不是 fire-and-forget）的动作，
+// 比如 get_login_info、get_msg 这类查询类接口
+func (c *cqclient) Call(action string, params interface{}) (*CQResponse, error) {
+	if c.apiURL == "" {
+		warnHTTPApiURLNotSet()
+		return nil, fmt.Errorf("coolq: http api url not set")
+	}
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.httpConn.PostJSON(c.getAPIURL(action), body)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	response := new(CQResponse)
+	if err := json.NewDecoder(res.Body).Decode(response); err != nil {
+		return nil, err
+	}
+	if response.RetCode != 0 {
+		return response, fmt.Errorf("coolq: action %s failed with ret_code %d", action, response.RetCode)
+	}
+	return response, nil
+}
+
+// GetLoginInfo 获取登录号信息
+// http 接口
+func (c *cqclient) GetLoginInfo() (*CQTypeGetLoginInfo, error) {
+	res, err := c.Call(ActionGetLoginInfo, nil)
+	if err != nil {
+		return nil, err
+	}
+	info := new(CQTypeGetLoginInfo)
+	return info, decodeCQData(res.Data, info)
+}
+
+// GetFriendList 获取好友列表
+// http 接口
+func (c *cqclient) GetFriendList() ([]CQTypeFriend, error) {
+	res, err := c.Call(ActionGetFriendList, nil)
+	if err != nil {
+		return nil, err
+	}
+	friends := make([]CQTypeFriend, 0)
+	return friends, decodeCQData(res.Data, &friends)
+}
+
+// GetGroupList 获取群列表
+// http 接口
+func (c *cqclient) GetGroupList() ([]CQTypeGroup, error) {
+	res, err := c.Call(ActionGetGroupList, nil)
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]CQTypeGroup, 0)
+	return groups, decodeCQData(res.Data, &groups)
+}
+
+// GetGroupMemberInfo 获取群成员信息
+// http 接口
+func (c *cqclient) GetGroupMemberInfo(groupID, userID int64, noCache bool) (*CQTypeGroupMember, error) {
+	res, err := c.Call(ActionGetGroupMemberInfo, CQTypeGetGroupMemberInfo{
+		GroupID: groupID,
+		UserID:  userID,
+		NoCache: noCache,
+	})
+	if err != nil {
+		return nil, err
+	}
+	member := new(CQTypeGroupMember)
+	return member, decodeCQData(res.Data, member)
+}
+
+// GetGroupMemberList 获取群成员列表
+// http 接口
+func (c *cqclient) GetGroupMemberList(groupID int64) ([]CQTypeGroupMember, error) {
+	res, err := c.Call(ActionGetGroupMemberList, CQTypeGetGroupMemberList{GroupID: groupID})
+	if err != nil {
+		return nil, err
+	}
+	members := make([]CQTypeGroupMember, 0)
+	return members, decodeCQData(res.Data, &members)
+}
+
+// SendMsg 发送消息，message_type 为空时由 group_id/user_id 是否为 0 自动判断
+// http 接口
+func (c *cqclient) SendMsg(params CQTypeSendMsg) (*CQTypeSendMsgResult, error) {
+	res, err := c.Call(ActionSendMsg, params)
+	if err != nil {
+		return nil, err
+	}
+	result := new(CQTypeSendMsgResult)
+	return result, decodeCQData(res.Data, result)
+}
+
+// DeleteMsg 撤回消息
+// http 接口
+func (c *cqclient) DeleteMsg(messageID int32) error {
+	_, err := c.Call(ActionDeleteMsg, CQTypeDeleteMsg{MessageID: messageID})
+	return err
+}
+
+// GetMsg 获取消息
+// http 接口
+func (c *cqclient) GetMsg(messageID int32) (*CQTypeMessage, error) {
+	res, err := c.Call(ActionGetMsg, CQTypeGetMsg{MessageID: messageID})
+	if err != nil {
+		return nil, err
+	}
+	msg := new(CQTypeMessage)
+	return msg, decodeCQData(res.Data, msg)
+}
+
+// GetForwardMsg 获取合并转发消息
+// http 接口
+func (c *cqclient) GetForwardMsg(id string) ([]CQTypeForwardNode, error) {
+	res, err := c.Call(ActionGetForwardMsg, CQTypeGetForwardMsg{ID: id})
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]CQTypeForwardNode, 0)
+	return nodes, decodeCQData(res.Data, &nodes)
+}
+
+// SendGroupForwardMsg 发送合并转发（群）
+// http 接口
+func (c *cqclient) SendGroupForwardMsg(groupID int64, nodes []CQTypeForwardNode) (*CQTypeSendMsgResult, error) {
+	res, err := c.Call(ActionSendGroupForwardMsg, CQTypeSendGroupForwardMsg{
+		GroupID: groupID,
+		Message: nodes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := new(CQTypeSendMsgResult)
+	return result, decodeCQData(res.Data, result)
+}
+
+// SetGroupCard 设置群名片
+// http 接口
+func (c *cqclient) SetGroupCard(groupID, userID int64, card string) error {
+	_, err := c.Call(ActionSetGroupCard, CQTypeSetGroupCard{GroupID: groupID, UserID: userID, Card: card})
+	return err
+}
+
+// SetGroupName 设置群名
+// http 接口
+func (c *cqclient) SetGroupName(groupID int64, groupName string) error {
+	_, err := c.Call(ActionSetGroupName, CQTypeSetGroupName{GroupID: groupID, GroupName: groupName})
+	return err
+}
+
+// SetGroupLeave 退出群组
+// http 接口
+func (c *cqclient) SetGroupLeave(groupID int64, isDismiss bool) error {
+	_, err := c.Call(ActionSetGroupLeave, CQTypeSetGroupLeave{GroupID: groupID, IsDismiss: isDismiss})
+	return err
+}
+
+// SetGroupAdmin 设置群管理员
+// http 接口
+func (c *cqclient) SetGroupAdmin(groupID, userID int64, enable bool) error {
+	_, err := c.Call(ActionSetGroupAdmin, CQTypeSetGroupAdmin{GroupID: groupID, UserID: userID, Enable: enable})
+	return err
+}
+
+// SetGroupAnonymousBan 群组匿名用户禁言
+// http 接口
+func (c *cqclient) SetGroupAnonymousBan(params CQTypeSetGroupAnonymousBan) error {
+	_, err := c.Call(ActionSetGroupAnonymousBan, params)
+	return err
+}
+
+// SetFriendAddRequest 处理加好友请求
+// http 接口
+func (c *cqclient) SetFriendAddRequest(flag string, approve bool, remark string) error {
+	_, err := c.Call(ActionSetFriendAddRequest, CQTypeSetFriendAddRequest{
+		Flag:    flag,
+		Approve: approve,
+		Remark:  remark,
+	})
+	return err
+}
+
+// SetGroupAddRequest 处理加群请求/邀请
+// http 接口
+func (c *cqclient) SetGroupAddRequest(flag, subType string, approve bool, reason string) error {
+	_, err := c.Call(ActionSetGroupAddRequest, CQTypeSetGroupAddRequest{
+		Flag:    flag,
+		SubType: subType,
+		Approve: approve,
+		Reason:  reason,
+	})
+	return err
+}
+
+// GetImage 获取图片信息
+// http 接口
+func (c *cqclient) GetImage(file string) (*CQTypeImageInfo, error) {
+	res, err := c.Call(ActionGetImage, CQTypeGetImage{File: file})
+	if err != nil {
+		return nil, err
+	}
+	info := new(CQTypeImageInfo)
+	return info, decodeCQData(res.Data, info)
+}
+
+// CanSendImage 检查是否可以发送图片
+// http 接口
+func (c *cqclient) CanSendImage() (bool, error) {
+	res, err := c.Call(ActionCanSendImage, nil)
+	if err != nil {
+		return false, err
+	}
+	can := new(CQTypeCanSend)
+	if err := decodeCQData(res.Data, can); err != nil {
+		return false, err
+	}
+	return can.Yes, nil
+}
+
+// CanSendRecord 检查是否可以发送语音
+// http 接口
+func (c *cqclient) CanSendRecord() (bool, error) {
+	res, err := c.Call(ActionCanSendRecord, nil)
+	if err != nil {
+		return false, err
+	}
+	can := new(CQTypeCanSend)
+	if err := decodeCQData(res.Data, can); err != nil {
+		return false, err
+	}
+	return can.Yes, nil
+}
+
+// GetCookies 获取 cookies
+// http 接口
+func (c *cqclient) GetCookies(domain string) (string, error) {
+	res, err := c.Call(ActionGetCookies, CQTypeCookies{Domain: domain})
+	if err != nil {
+		return "", err
+	}
+	cookies := new(CQTypeCookies)
+	if err := decodeCQData(res.Data, cookies); err != nil {
+		return "", err
+	}
+	return cookies.Cookies, nil
+}
+
+// GetCsrfToken 获取 csrf token
+// http 接口
+func (c *cqclient) GetCsrfToken() (int32, error) {
+	res, err := c.Call(ActionGetCsrfToken, nil)
+	if err != nil {
+		return 0, err
+	}
+	token := new(CQTypeCsrfToken)
+	if err := decodeCQData(res.Data, token); err != nil {
+		return 0, err
+	}
+	return token.Token, nil
+}
+
+// decodeCQData 把 CQResponse.Data（解析 json 后的 interface{}）重新编码再解码进
+// 具体的类型里，避免每个 action 的返回数据都手写一遍 map[string]interface{} 断言
+func decodeCQData(data interface{}, out interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, out)
+}
+
 // Client 唯一的酷q机器人实体
 var Client = &cqclient{
 	apiConn:       new(clients.WSClient),
 	eventConn:     new(clients.WSClient),
 	pluginEntries: make(map[string]pluginEntry),
+	echoqueue:     make(map[int64]chan *CQResponse),
+	clientName:    "default",
 }
@@ -0,0 +1,27 @@
+package coolq
+
+import "github.com/haruno-bot/haruno/coolq/message"
+
+// CQEvent 酷q/go-cqhttp 上报的事件
+// 字段对应 OneBot 的事件上报格式
+type CQEvent struct {
+	Time        int64  `json:"time"`
+	SelfID      int64  `json:"self_id"`
+	PostType    string `json:"post_type"`
+	MessageType string `json:"message_type,omitempty"`
+	SubType     string `json:"sub_type,omitempty"`
+	GroupID     int64  `json:"group_id,omitempty"`
+	UserID      int64  `json:"user_id,omitempty"`
+	Message     string `json:"message,omitempty"`
+	RawMessage  string `json:"raw_message,omitempty"`
+
+	// ClientName 标识这个事件来自 Manager 管理的哪一个 cqclient 实例，
+	// 由 dispatchEvent 在分发前赋值，不是上报数据本身的字段
+	// 单实例（coolq.Client）场景下固定是 "default"
+	ClientName string `json:"-"`
+
+	// ParsedMessage 是 Message 解析成消息段之后的结果，由 dispatchEvent
+	// 在分发给 filter/handler 之前统一解析好，filter/handler 可以直接按
+	// 段类型（at/image/...）匹配，不需要自己写正则
+	ParsedMessage message.Message `json:"-"`
+}
@@ -0,0 +1,80 @@
+package coolq
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/haruno-bot/haruno/logger"
+)
+
+// eventHTTPServer 反向 http 上报服务
+// 作为 eventConn (ws) 之外的另一条事件上报通道，go-cqhttp 可以配置
+// http post 上报模式，把事件 post 到这里，最终同样走 dispatchEvent
+// 分发给 pluginEntries
+type eventHTTPServer struct {
+	server *http.Server
+}
+
+// ListenEventHTTP 启动反向 http 上报服务
+// addr 形如 :5700，与 go-cqhttp 配置中的 http post 上报地址对应
+// secret 对应 go-cqhttp 配置里的上报 secret，为空时不校验签名
+func (c *cqclient) ListenEventHTTP(addr, secret string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			logger.Errorf("eventHTTPServer read body error: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if secret != "" && !verifySignature(secret, body, r.Header.Get("X-Signature")) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		event := new(CQEvent)
+		if err := json.Unmarshal(body, event); err != nil {
+			logger.Errorf("eventHTTPServer decode event error: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		c.dispatchEvent(event)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	c.httpEventServer = &eventHTTPServer{
+		server: &http.Server{Addr: addr, Handler: mux},
+	}
+	go func() {
+		if err := c.httpEventServer.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Errorf("eventHTTPServer closed unexpectedly: %v", err)
+		}
+	}()
+	logger.Successf("reverse http event server listening on %s", addr)
+	return nil
+}
+
+// verifySignature 校验 go-cqhttp http post 上报时带的 X-Signature 头
+// 其值形如 "sha1=<hex>"，是用配置的 secret 对原始请求体算的 HMAC-SHA1
+func verifySignature(secret string, body []byte, header string) bool {
+	const prefix = "sha1="
+	if !strings.HasPrefix(header, prefix) {
+		return false
+	}
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(strings.TrimPrefix(header, prefix)))
+}
+
+// CloseEventHTTP 关闭反向 http 上报服务
+func (c *cqclient) CloseEventHTTP(ctx context.Context) error {
+	if c.httpEventServer == nil || c.httpEventServer.server == nil {
+		return nil
+	}
+	return c.httpEventServer.server.Shutdown(ctx)
+}
@@ -0,0 +1,74 @@
+package coolq
+
+import (
+	"sync"
+
+	"github.com/haruno-bot/haruno/clients"
+	"github.com/haruno-bot/haruno/logger"
+)
+
+// Manager 管理多个 cqclient 实例
+// coolq.Client 原来是包级单例，一个进程只能连一个 coolq/go-cqhttp 实例，
+// Manager 让多账号、分片、或者 dev/prod 并存的场景也能共用同一套插件
+type Manager struct {
+	mu      sync.Mutex
+	clients map[string]*cqclient
+}
+
+// NewManager 创建一个空的 Manager
+func NewManager() *Manager {
+	return &Manager{clients: make(map[string]*cqclient)}
+}
+
+// DefaultManager 默认的管理器，coolq.Client 等价于 DefaultManager.Get("default")
+var DefaultManager = NewManager()
+
+func init() {
+	DefaultManager.mu.Lock()
+	DefaultManager.clients["default"] = Client
+	DefaultManager.mu.Unlock()
+}
+
+// Add 注册一个新的客户端，用 token/wsURL/httpURL 初始化并建立连接，
+// name 作为这个客户端的标识，会被打到它分发的每一个 CQEvent.ClientName 上
+func (m *Manager) Add(name, token, wsURL, httpURL string, opts ...InitOption) *cqclient {
+	c := &cqclient{
+		apiConn:       new(clients.WSClient),
+		eventConn:     new(clients.WSClient),
+		pluginEntries: make(map[string]pluginEntry),
+		echoqueue:     make(map[int64]chan *CQResponse),
+		clientName:    name,
+	}
+	c.Initialize(token, opts...)
+	c.Connect(wsURL, httpURL)
+	c.RegisterAllPlugins()
+	m.mu.Lock()
+	m.clients[name] = c
+	m.mu.Unlock()
+	return c
+}
+
+// Get 按名字取出一个已注册的客户端，不存在时返回 nil
+func (m *Manager) Get(name string) *cqclient {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.clients[name]
+}
+
+// Broadcast 把同一个 action 发往所有已注册的客户端，任意一个失败只记录日志，
+// 不影响其余客户端
+func (m *Manager) Broadcast(action string, params interface{}) {
+	m.mu.Lock()
+	targets := make([]*cqclient, 0, len(m.clients))
+	for _, c := range m.clients {
+		targets = append(targets, c)
+	}
+	m.mu.Unlock()
+	for _, c := range targets {
+		go func(c *cqclient) {
+			if _, err := c.Call(action, params); err != nil {
+				logger.Errorf("manager broadcast action %s to client %s failed: %v", action, c.clientName, err)
+			}
+		}(c)
+	}
+}
@@ -0,0 +1,128 @@
+package message
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// escapeText 转义纯文本段里的 CQ 码保留字符
+func escapeText(s string) string {
+	r := strings.NewReplacer("&", "&amp;", "[", "&#91;", "]", "&#93;")
+	return r.Replace(s)
+}
+
+// unescapeText 反转义
+func unescapeText(s string) string {
+	r := strings.NewReplacer("&#91;", "[", "&#93;", "]", "&amp;", "&")
+	return r.Replace(s)
+}
+
+// escapeParam 转义 CQ 码参数值，比纯文本多转义逗号
+func escapeParam(s string) string {
+	r := strings.NewReplacer("&", "&amp;", "[", "&#91;", "]", "&#93;", ",", "&#44;")
+	return r.Replace(s)
+}
+
+// unescapeParam 反转义
+func unescapeParam(s string) string {
+	r := strings.NewReplacer("&#44;", ",", "&#91;", "[", "&#93;", "]", "&amp;", "&")
+	return r.Replace(s)
+}
+
+// String 把消息渲染成 CQ 码字符串，例如 "[CQ:at,qq=123]hello"
+func (m Message) String() string {
+	var b strings.Builder
+	for _, seg := range m {
+		if seg.Type == "text" {
+			b.WriteString(escapeText(seg.Data["text"]))
+			continue
+		}
+		b.WriteString("[CQ:")
+		b.WriteString(seg.Type)
+		keys := make([]string, 0, len(seg.Data))
+		for k := range seg.Data {
+			keys = append(keys, k)
+		}
+		// Data 是 map，遍历顺序不固定；按 key 排序保证同一个 Segment
+		// 每次渲染出来的 CQ 码都是一样的，方便比较和写测试
+		sort.Strings(keys)
+		for _, k := range keys {
+			b.WriteByte(',')
+			b.WriteString(k)
+			b.WriteByte('=')
+			b.WriteString(escapeParam(seg.Data[k]))
+		}
+		b.WriteByte(']')
+	}
+	return b.String()
+}
+
+// arraySegment OneBot 消息段数组格式的一个元素
+type arraySegment struct {
+	Type string            `json:"type"`
+	Data map[string]string `json:"data"`
+}
+
+// MarshalJSON 按 OneBot 消息段数组格式编码
+func (m Message) MarshalJSON() ([]byte, error) {
+	segs := make([]arraySegment, 0, len(m))
+	for _, seg := range m {
+		segs = append(segs, arraySegment{Type: seg.Type, Data: seg.Data})
+	}
+	return json.Marshal(segs)
+}
+
+// UnmarshalJSON 按 OneBot 消息段数组格式解码
+func (m *Message) UnmarshalJSON(raw []byte) error {
+	segs := make([]arraySegment, 0)
+	if err := json.Unmarshal(raw, &segs); err != nil {
+		return err
+	}
+	result := make(Message, 0, len(segs))
+	for _, seg := range segs {
+		result = append(result, Segment{Type: seg.Type, Data: seg.Data})
+	}
+	*m = result
+	return nil
+}
+
+// Parse 把一段 CQ 码字符串解析为 Message
+// 既可以解析纯文本消息，也可以解析携带 [CQ:xxx,k=v] 片段的消息
+func Parse(raw string) Message {
+	msg := make(Message, 0)
+	for len(raw) > 0 {
+		start := strings.Index(raw, "[CQ:")
+		if start == -1 {
+			msg = append(msg, Text(unescapeText(raw)))
+			break
+		}
+		if start > 0 {
+			msg = append(msg, Text(unescapeText(raw[:start])))
+		}
+		end := strings.Index(raw[start:], "]")
+		if end == -1 {
+			// 没有找到闭合的]，剩余部分当作纯文本处理
+			msg = append(msg, Text(unescapeText(raw[start:])))
+			break
+		}
+		end += start
+		msg = append(msg, parseSegment(raw[start+len("[CQ:"):end]))
+		raw = raw[end+1:]
+	}
+	return msg
+}
+
+// parseSegment 解析形如 "at,qq=123" 的 CQ 码内容（已去掉 [CQ: 和 ]）
+func parseSegment(body string) Segment {
+	parts := strings.Split(body, ",")
+	seg := Segment{Type: parts[0], Data: make(map[string]string)}
+	for _, part := range parts[1:] {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		seg.Data[kv[0]] = unescapeParam(kv[1])
+	}
+	return seg
+}
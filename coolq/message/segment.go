@@ -0,0 +1,88 @@
+// Package message 提供 CQ 码与 OneBot 消息段数组两种格式的构造与解析
+// 插件不再需要手工拼接形如 [CQ:at,qq=123] 的字符串
+package message
+
+// Segment 一个消息段
+// Type 对应 CQ 码中的功能名，比如 "at"、"image"；Data 对应各个 key=value
+type Segment struct {
+	Type string
+	Data map[string]string
+}
+
+// Message 由多个 Segment 组成的完整消息
+type Message []Segment
+
+// Text 纯文本
+func Text(text string) Segment {
+	return Segment{Type: "text", Data: map[string]string{"text": text}}
+}
+
+// At @某人，qq 传 "all" 表示@全体成员
+func At(qq string) Segment {
+	return Segment{Type: "at", Data: map[string]string{"qq": qq}}
+}
+
+// Face qq表情，id 参考 coolq 表情 id 表
+func Face(id string) Segment {
+	return Segment{Type: "face", Data: map[string]string{"id": id}}
+}
+
+// Image 图片，file 可以是本地路径、网络 url 或 base64
+func Image(file string) Segment {
+	return Segment{Type: "image", Data: map[string]string{"file": file}}
+}
+
+// Record 语音，file 同 Image
+func Record(file string) Segment {
+	return Segment{Type: "record", Data: map[string]string{"file": file}}
+}
+
+// Reply 回复消息
+func Reply(messageID string) Segment {
+	return Segment{Type: "reply", Data: map[string]string{"id": messageID}}
+}
+
+// Forward 合并转发，id 为 get_forward_msg 可用的转发消息 id
+func Forward(id string) Segment {
+	return Segment{Type: "forward", Data: map[string]string{"id": id}}
+}
+
+// Node 合并转发节点，configured via send_group_forward_msg 的 id 引用型节点
+func Node(id string) Segment {
+	return Segment{Type: "node", Data: map[string]string{"id": id}}
+}
+
+// Music 音乐分享，kind 为 qq/163/xm 等平台标识
+func Music(kind, id string) Segment {
+	return Segment{Type: "music", Data: map[string]string{"type": kind, "id": id}}
+}
+
+// Share 链接分享
+func Share(url, title string) Segment {
+	return Segment{Type: "share", Data: map[string]string{"url": url, "title": title}}
+}
+
+// Gift 群礼物
+func Gift(qq, id string) Segment {
+	return Segment{Type: "gift", Data: map[string]string{"qq": qq, "id": id}}
+}
+
+// Redbag 红包
+func Redbag(title string) Segment {
+	return Segment{Type: "redbag", Data: map[string]string{"title": title}}
+}
+
+// TTS 文本转语音
+func TTS(text string) Segment {
+	return Segment{Type: "tts", Data: map[string]string{"text": text}}
+}
+
+// XML 自定义xml消息
+func XML(data string) Segment {
+	return Segment{Type: "xml", Data: map[string]string{"data": data}}
+}
+
+// JSON 自定义json消息
+func JSON(data string) Segment {
+	return Segment{Type: "json", Data: map[string]string{"data": data}}
+}
@@ -0,0 +1,77 @@
+package coolq
+
+import (
+	"context"
+
+	"github.com/haruno-bot/haruno/coolq/message"
+)
+
+// MessageFormat 发送消息时，Message 字段使用 CQ 码字符串还是消息段数组编码
+type MessageFormat int
+
+const (
+	// MessageFormatCQCode CQ 码字符串格式，比如 "[CQ:at,qq=123]hello"（默认）
+	MessageFormatCQCode MessageFormat = iota
+	// MessageFormatArray OneBot 消息段数组格式
+	MessageFormatArray
+)
+
+// cqSendMessageParams send_msg/send_group_msg/send_private_msg 的参数
+// Message 既可以是 CQ 码字符串，也可以是 message.Message（数组格式），
+// 具体取决于 cqclient.messageFormat
+type cqSendMessageParams struct {
+	GroupID int64       `json:"group_id,omitempty"`
+	UserID  int64       `json:"user_id,omitempty"`
+	Message interface{} `json:"message"`
+}
+
+// SetMessageFormat 设置 SendGroupMessage/SendPrivateMessage 发送消息时使用的编码格式
+func (c *cqclient) SetMessageFormat(format MessageFormat) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.messageFormat = format
+}
+
+// encodeMessage 依据当前的 messageFormat 把 Message 编码成发送参数里用的形式
+func (c *cqclient) encodeMessage(msg message.Message) interface{} {
+	if c.messageFormat == MessageFormatArray {
+		return msg
+	}
+	return msg.String()
+}
+
+// SendGroupMessage 以消息段的方式发送群消息，等价于拼好 CQ 码再调 SendGroupMsg，
+// 但不需要插件自己手动转义/拼接字符串
+// websocket 接口
+func (c *cqclient) SendGroupMessage(groupID int64, msg message.Message) (*CQTypeSendMsgResult, error) {
+	res, err := c.CallContext(context.Background(), ActionSendGroupMsg, cqSendMessageParams{
+		GroupID: groupID,
+		Message: c.encodeMessage(msg),
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := new(CQTypeSendMsgResult)
+	return result, decodeCQData(res.Data, result)
+}
+
+// SendPrivateMessage 以消息段的方式发送私聊消息
+// websocket 接口
+func (c *cqclient) SendPrivateMessage(userID int64, msg message.Message) (*CQTypeSendMsgResult, error) {
+	res, err := c.CallContext(context.Background(), ActionSendPrivateMsg, cqSendMessageParams{
+		UserID:  userID,
+		Message: c.encodeMessage(msg),
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := new(CQTypeSendMsgResult)
+	return result, decodeCQData(res.Data, result)
+}
+
+// Segments 返回上报事件解析好的消息段，方便 filter/handler 按
+// CQ:at、CQ:image 等段类型做匹配，而不是自己写正则
+// 解析本身在 dispatchEvent 里统一做过了，这里直接取缓存结果
+func (event *CQEvent) Segments() message.Message {
+	return event.ParsedMessage
+}
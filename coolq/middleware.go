@@ -0,0 +1,70 @@
+package coolq
+
+import "github.com/haruno-bot/haruno/logger"
+
+// Middleware 包装一个 Handler，用于实现横切关注点
+// 比如按插件维度打点、按群/用户限流等需要对所有插件都生效的逻辑
+// 命令前缀裁剪不适合做成这种全局中间件（见 CommandStart），因为不是所有
+// 事件/插件都是以命令形式触发的，全局生效会把其它事件一并吞掉
+type Middleware func(next Handler) Handler
+
+// Use 注册中间件，按注册顺序由外到内包裹每一个 handler
+// 即先注册的先执行，最后才真正调用原始 handler
+func (c *cqclient) Use(mws ...Middleware) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.middlewares = append(c.middlewares, mws...)
+}
+
+// chain 把已注册的中间件按顺序包裹在 handler 外面
+func (c *cqclient) chain(handler Handler) Handler {
+	c.mu.Lock()
+	mws := c.middlewares
+	c.mu.Unlock()
+	for i := len(mws) - 1; i >= 0; i-- {
+		handler = mws[i](handler)
+	}
+	return handler
+}
+
+// withRecover 捕获 handler 执行时的 panic 并记录日志，附带插件名便于定位
+// worker pool 会无条件套上这一层，避免某个插件的 bug 拖垮整个进程
+func withRecover(pluginName string, handler Handler) Handler {
+	return func(event *CQEvent) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Errorf("plugin %s panic while handling event: %v", pluginName, r)
+			}
+		}()
+		handler(event)
+	}
+}
+
+// CommandStart 包装一个具体的 Handler，仿照 leafbot 的 command_start：
+// 消息以给定前缀之一开头时才会调用 handler，并且传给它的事件里前缀已经被裁掉
+// 它刻意不是 Middleware，不能通过 Use 注册成对所有插件都生效的全局逻辑——
+// 插件应该在自己的 Handlers() 里对需要命令匹配的那个 key 单独包一层，
+// 否则全局生效会把没有任何命令前缀的事件（普通聊天、群事件等）也一并吞掉，
+// 导致其它插件完全收不到事件
+// 传给 handler 的是裁剪后事件的一份拷贝，不会修改调用方持有的原始 *CQEvent，
+// 这样同一个事件被多个插件并发处理时互不影响
+func CommandStart(handler Handler, prefixes ...string) Handler {
+	return func(event *CQEvent) {
+		for _, prefix := range prefixes {
+			if trimmed, ok := cutPrefix(event.Message, prefix); ok {
+				copied := *event
+				copied.Message = trimmed
+				handler(&copied)
+				return
+			}
+		}
+	}
+}
+
+// cutPrefix 是 strings.CutPrefix 的退化实现（兼容没有该函数的 go 版本）
+func cutPrefix(s, prefix string) (string, bool) {
+	if len(s) < len(prefix) || s[:len(prefix)] != prefix {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
@@ -0,0 +1,65 @@
+package coolq
+
+import "github.com/haruno-bot/haruno/logger"
+
+// dispatchJob 待处理的一次 (插件, key, 事件) 三元组
+// key 为 noFilterKey 时对应没有 filter 的 handler
+type dispatchJob struct {
+	pluginName string
+	key        string
+	handler    Handler
+	event      *CQEvent
+}
+
+// workerPool 固定数量 worker 的任务队列，替代原来一个事件对一个插件
+// 起一个 goroutine 的做法，避免在活跃群里把 goroutine 数量打爆
+type workerPool struct {
+	jobs chan dispatchJob
+	done chan struct{}
+}
+
+// newWorkerPool 创建并启动一个 worker pool，size 为并发 worker 数量，
+// queueSize 为任务队列长度，队列满时 dispatchEvent 会丢弃新任务并打日志
+func newWorkerPool(size, queueSize int) *workerPool {
+	pool := &workerPool{
+		jobs: make(chan dispatchJob, queueSize),
+		done: make(chan struct{}),
+	}
+	for i := 0; i < size; i++ {
+		go pool.runWorker()
+	}
+	return pool
+}
+
+func (p *workerPool) runWorker() {
+	for {
+		select {
+		case job := <-p.jobs:
+			withRecover(job.pluginName, job.handler)(job.event)
+		case <-p.done:
+			// 把已经入队的任务消费完再退出
+			for {
+				select {
+				case job := <-p.jobs:
+					withRecover(job.pluginName, job.handler)(job.event)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// submit 把一个任务放进队列，队列满时立即丢弃并记录日志，而不是阻塞调用方
+func (p *workerPool) submit(job dispatchJob) {
+	select {
+	case p.jobs <- job:
+	default:
+		logger.Errorf("worker pool queue is full, drop event for plugin %s", job.pluginName)
+	}
+}
+
+// stop 关闭 worker pool，已经入队的任务会继续被消费完
+func (p *workerPool) stop() {
+	close(p.done)
+}